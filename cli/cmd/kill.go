@@ -28,27 +28,30 @@ func runKill(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid port number: %s", args[0])
 	}
 
-	s := scanner.New()
-	ports, err := s.Scan()
+	ports, err := listPorts()
 	if err != nil {
-		return fmt.Errorf("failed to scan ports: %w", err)
+		return err
 	}
 
-	// Find the process on this port
-	var target *scanner.Port
+	// Find the process(es) on this port, optionally disambiguated by --proto
+	var matches []scanner.Port
 	for _, p := range ports {
 		if p.Port == port {
-			target = &p
-			break
+			matches = append(matches, p)
 		}
 	}
 
-	if target == nil {
+	if len(matches) == 0 {
 		return fmt.Errorf("no process found listening on port %d", port)
 	}
+	if len(matches) > 1 {
+		return fmt.Errorf("multiple processes listening on port %d (use --proto tcp|udp to disambiguate)", port)
+	}
+
+	target := &matches[0]
 
 	// Kill the process
-	if err := s.Kill(target.PID, forceKill); err != nil {
+	if err := killPID(target.PID, forceKill); err != nil {
 		return fmt.Errorf("failed to kill process %d: %w", target.PID, err)
 	}
 
@@ -4,9 +4,15 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var bindFlag string
+
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all listening ports",
-	Long:  `List all TCP ports currently in LISTEN state with their associated processes.`,
+	Long:  `List all listening ports with their associated processes. Use --proto to filter by tcp, udp, or all (default tcp), and --bind to show only ports bound to a specific address.`,
 	RunE:  runList,
 }
+
+func init() {
+	listCmd.Flags().StringVar(&bindFlag, "bind", "", "Only show ports bound to this address (wildcard binds always match)")
+}
@@ -1,19 +1,25 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"sort"
+	"strings"
 	"text/tabwriter"
 
+	"github.com/productdevbook/port-killer/cli/internal/client"
 	"github.com/productdevbook/port-killer/cli/internal/scanner"
 	"github.com/spf13/cobra"
 )
 
 var (
-	version   = "0.1.0"
-	jsonOutput bool
+	version              = "0.1.0"
+	jsonOutput           bool
+	protoFlag            string
+	includeLocalhostFlag bool
+	wideOutput           bool
 )
 
 var rootCmd = &cobra.Command{
@@ -31,16 +37,83 @@ func Execute() {
 
 func init() {
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	rootCmd.PersistentFlags().StringVar(&protoFlag, "proto", scanner.ProtoTCP, "Protocol to show: tcp, udp, or all")
+	rootCmd.PersistentFlags().BoolVar(&includeLocalhostFlag, "include-localhost", true, "Include ports bound to a loopback address")
+	rootCmd.PersistentFlags().BoolVar(&wideOutput, "wide", false, "Show extra columns: command, cwd, ppid, started")
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(killCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(serveCmd)
 	rootCmd.Version = version
 }
 
-func runList(cmd *cobra.Command, args []string) error {
+// filterProto drops ports whose Proto doesn't match protoFlag ("all" keeps
+// everything).
+func filterProto(ports []scanner.Port) ([]scanner.Port, error) {
+	switch protoFlag {
+	case scanner.ProtoTCP, scanner.ProtoUDP:
+		filtered := ports[:0]
+		for _, p := range ports {
+			if p.Proto == protoFlag {
+				filtered = append(filtered, p)
+			}
+		}
+		return filtered, nil
+	case scanner.ProtoAll:
+		return ports, nil
+	default:
+		return nil, fmt.Errorf("invalid --proto %q: must be tcp, udp, or all", protoFlag)
+	}
+}
+
+// scanOptions builds scanner.ScanOptions from the --include-localhost and
+// --bind flags.
+func scanOptions() scanner.ScanOptions {
+	return scanner.ScanOptions{
+		IncludeLocalhost: includeLocalhostFlag,
+		Bind:             bindFlag,
+	}
+}
+
+// listPorts returns the current listening ports, talking to a running
+// "portkiller serve" daemon over PORTKILLER_SOCKET when set, or scanning
+// directly otherwise.
+func listPorts() ([]scanner.Port, error) {
+	if socketPath := os.Getenv(client.SocketEnvVar); socketPath != "" {
+		c, err := client.Dial(socketPath)
+		if err != nil {
+			return nil, err
+		}
+		defer c.Close()
+		return c.List(context.Background(), protoFlag, scanOptions())
+	}
+
 	s := scanner.New()
-	ports, err := s.Scan()
+	ports, err := s.Scan(scanOptions())
 	if err != nil {
-		return fmt.Errorf("failed to scan ports: %w", err)
+		return nil, fmt.Errorf("failed to scan ports: %w", err)
+	}
+	return filterProto(ports)
+}
+
+// killPID terminates pid, via a running daemon over PORTKILLER_SOCKET when
+// set, or directly otherwise.
+func killPID(pid int, force bool) error {
+	if socketPath := os.Getenv(client.SocketEnvVar); socketPath != "" {
+		c, err := client.Dial(socketPath)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		return c.Kill(context.Background(), pid, force)
+	}
+	return scanner.New().Kill(pid, force)
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	ports, err := listPorts()
+	if err != nil {
+		return err
 	}
 
 	if len(ports) == 0 {
@@ -52,9 +125,12 @@ func runList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Sort by port number
+	// Sort by port number, then protocol
 	sort.Slice(ports, func(i, j int) bool {
-		return ports[i].Port < ports[j].Port
+		if ports[i].Port != ports[j].Port {
+			return ports[i].Port < ports[j].Port
+		}
+		return ports[i].Proto < ports[j].Proto
 	})
 
 	if jsonOutput {
@@ -72,16 +148,42 @@ func printJSON(ports []scanner.Port) error {
 
 func printTable(ports []scanner.Port) error {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "PORT\tPID\tPROCESS\tUSER\tADDRESS")
-	fmt.Fprintln(w, "----\t---\t-------\t----\t-------")
+
+	if wideOutput {
+		fmt.Fprintln(w, "PORT\tPROTO\tPID\tPPID\tPROCESS\tUSER\tADDRESS\tCOMMAND\tCWD\tSTARTED")
+		fmt.Fprintln(w, "----\t-----\t---\t----\t-------\t----\t-------\t-------\t---\t-------")
+	} else {
+		fmt.Fprintln(w, "PORT\tPROTO\tPID\tPROCESS\tUSER\tADDRESS")
+		fmt.Fprintln(w, "----\t-----\t---\t-------\t----\t-------")
+	}
 
 	for _, p := range ports {
 		user := p.User
 		if user == "" {
 			user = "-"
 		}
-		fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\n", p.Port, p.PID, p.Process, user, p.Address)
+
+		if wideOutput {
+			started := "-"
+			if !p.StartedAt.IsZero() {
+				started = p.StartedAt.Format("2006-01-02 15:04:05")
+			}
+			command := orDash(p.Command)
+			cwd := orDash(p.Cwd)
+			fmt.Fprintf(w, "%d\t%s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				p.Port, strings.ToUpper(p.Proto), p.PID, p.PPID, p.Process, user, p.Address, command, cwd, started)
+			continue
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\t%s\n", p.Port, strings.ToUpper(p.Proto), p.PID, p.Process, user, p.Address)
 	}
 
 	return w.Flush()
 }
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
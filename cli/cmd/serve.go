@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/productdevbook/port-killer/cli/internal/scanner"
+	"github.com/productdevbook/port-killer/cli/internal/server"
+	"github.com/spf13/cobra"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run the portkiller control daemon",
+	Long:  `Serve exposes a gRPC API over a Unix domain socket (~/.portkiller/portkiller.sock) so the GUI and any number of CLI invocations can share one scanner. Point a CLI at a running daemon with PORTKILLER_SOCKET.`,
+	RunE:  runServe,
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	lis, err := server.Listen()
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	defer lis.Close()
+
+	srv := server.New(scanner.New())
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("portkiller daemon listening on %s\n", lis.Addr())
+
+	return server.Serve(ctx, lis, srv)
+}
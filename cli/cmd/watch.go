@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/productdevbook/port-killer/cli/internal/config"
+	"github.com/productdevbook/port-killer/cli/internal/scanner"
+	"github.com/productdevbook/port-killer/cli/internal/watcher"
+	"github.com/spf13/cobra"
+)
+
+var watchInterval time.Duration
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch configured ports and notify on changes",
+	Long:  `Watch polls the ports configured in the shared config's watched list and fires a desktop notification whenever one starts or stops listening.`,
+	RunE:  runWatch,
+}
+
+func init() {
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 2*time.Second, "Polling interval")
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	store := config.NewStore()
+	cfg, err := store.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if len(cfg.WatchedPorts) == 0 {
+		fmt.Println("No watched ports configured. Add one from the portkiller GUI or config file.")
+		return nil
+	}
+
+	w := watcher.New(scanner.New(), store, watcher.NewNotifier(), watchInterval, scanOptions())
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("Watching %d port(s) every %s. Press Ctrl+C to stop.\n", len(cfg.WatchedPorts), watchInterval)
+
+	if err := w.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Package client is a thin gRPC client for the portkiller daemon
+// (see internal/server), used by the CLI's list/kill commands when
+// PORTKILLER_SOCKET points at a running "portkiller serve" instance.
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/productdevbook/port-killer/cli/internal/scanner"
+	"github.com/productdevbook/port-killer/cli/internal/server/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// SocketEnvVar is the environment variable the CLI checks to decide whether
+// to talk to a running daemon instead of scanning directly. Its value is
+// the Unix domain socket path (or named pipe path on Windows).
+const SocketEnvVar = "PORTKILLER_SOCKET"
+
+// Client talks to a running "portkiller serve" daemon over a Unix domain
+// socket (or named pipe on Windows).
+type Client struct {
+	conn *grpc.ClientConn
+	rpc  pb.PortKillerClient
+}
+
+// Dial connects to the daemon listening on socketPath (a named pipe path on
+// windows).
+func Dial(socketPath string) (*Client, error) {
+	target, opts := dialOptions(socketPath)
+	opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+	conn, err := grpc.Dial(target, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial portkiller daemon at %s: %w", socketPath, err)
+	}
+	return &Client{conn: conn, rpc: pb.NewPortKillerClient(conn)}, nil
+}
+
+// Close releases the underlying connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// List asks the daemon for the current set of listening ports.
+func (c *Client) List(ctx context.Context, proto string, opts scanner.ScanOptions) ([]scanner.Port, error) {
+	resp, err := c.rpc.List(ctx, &pb.ListRequest{
+		Proto:            proto,
+		IncludeLocalhost: opts.IncludeLocalhost,
+		Bind:             opts.Bind,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ports := make([]scanner.Port, len(resp.GetPorts()))
+	for i, p := range resp.GetPorts() {
+		ports[i] = fromPBPort(p)
+	}
+	return ports, nil
+}
+
+// Kill asks the daemon to terminate pid.
+func (c *Client) Kill(ctx context.Context, pid int, force bool) error {
+	_, err := c.rpc.Kill(ctx, &pb.KillRequest{Pid: int32(pid), Force: force})
+	return err
+}
+
+// Watch streams port events from the daemon until ctx is cancelled. Every
+// received event is passed to fn.
+func (c *Client) Watch(ctx context.Context, proto string, opts scanner.ScanOptions, fn func(kind pb.PortEventKind, p scanner.Port) error) error {
+	stream, err := c.rpc.Watch(ctx, &pb.WatchRequest{
+		Proto:            proto,
+		IncludeLocalhost: opts.IncludeLocalhost,
+		Bind:             opts.Bind,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if err := fn(event.GetKind(), fromPBPort(event.GetPort())); err != nil {
+			return err
+		}
+	}
+}
+
+func fromPBPort(p *pb.Port) scanner.Port {
+	port := scanner.Port{
+		Port:    int(p.GetPort()),
+		Proto:   p.GetProto(),
+		PID:     int(p.GetPid()),
+		Process: p.GetProcess(),
+		User:    p.GetUser(),
+		Address: p.GetAddress(),
+		Command: p.GetCommand(),
+		Cwd:     p.GetCwd(),
+		PPID:    int(p.GetPpid()),
+	}
+	if startedAt := p.GetStartedAt(); startedAt != 0 {
+		port.StartedAt = time.Unix(startedAt, 0)
+	}
+	return port
+}
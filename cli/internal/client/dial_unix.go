@@ -0,0 +1,11 @@
+//go:build !windows
+
+package client
+
+import "google.golang.org/grpc"
+
+// dialOptions builds the grpc.Dial target and options for a Unix
+// domain socket path.
+func dialOptions(socketPath string) (string, []grpc.DialOption) {
+	return "unix:" + socketPath, nil
+}
@@ -0,0 +1,20 @@
+//go:build windows
+
+package client
+
+import (
+	"context"
+	"net"
+
+	"github.com/Microsoft/go-winio"
+	"google.golang.org/grpc"
+)
+
+// dialOptions builds the grpc.Dial target and options for a named
+// pipe path, since grpc has no built-in named pipe scheme.
+func dialOptions(pipePath string) (string, []grpc.DialOption) {
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) {
+		return winio.DialPipeContext(ctx, pipePath)
+	}
+	return "passthrough:" + pipePath, []grpc.DialOption{grpc.WithContextDialer(dialer)}
+}
@@ -13,29 +13,113 @@ import (
 	"time"
 )
 
+// darwinLstartLayout matches `ps -o lstart=` output, e.g. "Tue Jul 28 10:23:45 2026".
+const darwinLstartLayout = "Mon Jan 2 15:04:05 2006"
+
 type darwinScanner struct{}
 
 func newPlatformScanner() Scanner {
 	return &darwinScanner{}
 }
 
-func (s *darwinScanner) Scan() ([]Port, error) {
+func (s *darwinScanner) Scan(opts ScanOptions) ([]Port, error) {
+	return s.AppendListening(nil, opts)
+}
+
+func (s *darwinScanner) AppendListening(dst []Port, opts ScanOptions) ([]Port, error) {
 	// Run lsof to get listening TCP ports
 	cmd := exec.Command("lsof", "-iTCP", "-sTCP:LISTEN", "-P", "-n", "+c", "0")
 	output, err := cmd.Output()
 	if err != nil {
 		// lsof returns exit code 1 when no results, that's ok
-		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
-			return []Port{}, nil
+		if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 {
+			return dst, err
+		}
+	} else {
+		if dst, err = appendLsofOutput(dst, output, ProtoTCP, opts); err != nil {
+			return dst, err
+		}
+	}
+
+	// UDP sockets have no LISTEN state; any bound socket lsof reports counts.
+	// Best-effort: if this fails we still return what the TCP pass found.
+	if udpOutput, err := exec.Command("lsof", "-iUDP", "-P", "-n", "+c", "0").Output(); err == nil {
+		if dst, err = appendLsofOutput(dst, udpOutput, ProtoUDP, opts); err != nil {
+			return dst, err
 		}
-		return nil, err
 	}
 
-	return parseLsofOutput(output)
+	enrichWithProcessDetails(dst)
+
+	return dst, nil
 }
 
-func parseLsofOutput(output []byte) ([]Port, error) {
-	var ports []Port
+// enrichWithProcessDetails fills in Command, PPID, and StartedAt for every
+// port's PID with a single batched ps call, rather than one exec per port.
+func enrichWithProcessDetails(ports []Port) {
+	pids := make(map[int]bool, len(ports))
+	for _, p := range ports {
+		if p.PID > 0 {
+			pids[p.PID] = true
+		}
+	}
+	if len(pids) == 0 {
+		return
+	}
+
+	args := make([]string, 0, len(pids))
+	for pid := range pids {
+		args = append(args, strconv.Itoa(pid))
+	}
+
+	cmd := exec.Command("ps", "-o", "pid=,command=,ppid=,lstart=", "-p", strings.Join(args, ","))
+	output, err := cmd.Output()
+	if err != nil {
+		return
+	}
+
+	details := make(map[int]struct {
+		command   string
+		ppid      int
+		startedAt time.Time
+	}, len(pids))
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// pid command... ppid weekday month day time year
+		if len(fields) < 7 {
+			continue
+		}
+
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+
+		lstart := strings.Join(fields[len(fields)-5:], " ")
+		startedAt, _ := time.ParseInLocation(darwinLstartLayout, lstart, time.Local)
+
+		ppid, _ := strconv.Atoi(fields[len(fields)-6])
+		command := strings.Join(fields[1:len(fields)-6], " ")
+
+		details[pid] = struct {
+			command   string
+			ppid      int
+			startedAt time.Time
+		}{command, ppid, startedAt}
+	}
+
+	for i := range ports {
+		if d, ok := details[ports[i].PID]; ok {
+			ports[i].Command = d.command
+			ports[i].PPID = d.ppid
+			ports[i].StartedAt = d.startedAt
+		}
+	}
+}
+
+func appendLsofOutput(dst []Port, output []byte, proto string, opts ScanOptions) ([]Port, error) {
 	seen := make(map[string]bool)
 
 	scanner := bufio.NewScanner(bytes.NewReader(output))
@@ -77,8 +161,12 @@ func parseLsofOutput(output []byte) ([]Port, error) {
 			continue
 		}
 
+		if !shouldInclude(address, opts) {
+			continue
+		}
+
 		// Deduplicate by port+pid
-		key := strconv.Itoa(port) + ":" + strconv.Itoa(pid)
+		key := proto + ":" + strconv.Itoa(port) + ":" + strconv.Itoa(pid)
 		if seen[key] {
 			continue
 		}
@@ -87,8 +175,9 @@ func parseLsofOutput(output []byte) ([]Port, error) {
 		// Unescape process name (e.g., "Code\x20Helper" -> "Code Helper")
 		process = unescapeProcessName(process)
 
-		ports = append(ports, Port{
+		dst = append(dst, Port{
 			Port:    port,
+			Proto:   proto,
 			PID:     pid,
 			Process: process,
 			User:    user,
@@ -96,7 +185,7 @@ func parseLsofOutput(output []byte) ([]Port, error) {
 		})
 	}
 
-	return ports, nil
+	return dst, nil
 }
 
 func unescapeProcessName(name string) string {
@@ -5,7 +5,13 @@ package scanner
 import (
 	"bufio"
 	"bytes"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
 	"os/exec"
+	"os/user"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -13,48 +19,533 @@ import (
 	"time"
 )
 
-type linuxScanner struct{}
+// listenRow is one bound-socket row parsed out of /proc/net/{tcp,udp}{,6}.
+type listenRow struct {
+	proto   string
+	address string
+	port    int
+	inode   uint64
+}
+
+// pidEntry caches what we know about a pid so repeated polls don't have to
+// re-walk /proc/<pid>/fd unless something about that pid actually changed.
+type pidEntry struct {
+	statModTime time.Time
+	inodes      map[uint64]bool
+	process     string
+	user        string
+	command     string
+	cwd         string
+	ppid        int
+	startedAt   time.Time
+}
+
+// linuxScanner owns the /proc/net file handles for its whole lifetime and
+// reuses them across polls: Seek(0,0)+re-read picks up the kernel's latest
+// contents without the fork+exec cost of shelling out to lsof/ss on every
+// call. The inode->pid map is rebuilt incrementally: when a poll turns up an
+// inode not yet in the map, every live pid's fd set is re-walked (polls with
+// no new inode skip the walk), but the more expensive process metadata (cmdline, cwd,
+// user, stat) is only re-read for pids whose /proc/<pid>/stat mtime changed
+// or that now own an inode we hadn't seen before.
+type linuxScanner struct {
+	tcpFile  *os.File
+	tcp6File *os.File
+	udpFile  *os.File
+	udp6File *os.File
+
+	useLsofFallback bool
+
+	pids       map[int]*pidEntry
+	inodeToPID map[uint64]int
+
+	bootTime time.Time
+}
 
 func newPlatformScanner() Scanner {
-	return &linuxScanner{}
+	s := &linuxScanner{
+		pids:       make(map[int]*pidEntry),
+		inodeToPID: make(map[uint64]int),
+		bootTime:   readBootTime(),
+	}
+
+	var err error
+	if s.tcpFile, err = os.Open("/proc/net/tcp"); err != nil {
+		s.useLsofFallback = true
+		return s
+	}
+	s.tcp6File, _ = os.Open("/proc/net/tcp6") // best-effort, IPv6 may be disabled
+	s.udpFile, _ = os.Open("/proc/net/udp")
+	s.udp6File, _ = os.Open("/proc/net/udp6")
+
+	return s
+}
+
+func (s *linuxScanner) Scan(opts ScanOptions) ([]Port, error) {
+	return s.AppendListening(nil, opts)
+}
+
+func (s *linuxScanner) AppendListening(dst []Port, opts ScanOptions) ([]Port, error) {
+	if s.useLsofFallback {
+		return s.appendListeningViaLsof(dst, opts)
+	}
+
+	rows, err := s.readListenRows()
+	if err != nil {
+		// The /proc files we opened at startup stopped working (e.g. a
+		// container remount); fall back rather than erroring forever.
+		s.useLsofFallback = true
+		return s.appendListeningViaLsof(dst, opts)
+	}
+
+	s.refreshPIDCache(rows)
+
+	seen := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		if !shouldInclude(row.address, opts) {
+			continue
+		}
+
+		key := row.proto + ":" + strconv.Itoa(row.port) + ":" + strconv.FormatUint(row.inode, 10)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		pid := s.inodeToPID[row.inode]
+		entry := s.pids[pid]
+
+		port := Port{
+			Port:    row.port,
+			Proto:   row.proto,
+			PID:     pid,
+			Address: row.address,
+		}
+		if entry != nil {
+			port.Process = entry.process
+			port.User = entry.user
+			port.Command = entry.command
+			port.Cwd = entry.cwd
+			port.PPID = entry.ppid
+			port.StartedAt = entry.startedAt
+		}
+		dst = append(dst, port)
+	}
+
+	return dst, nil
+}
+
+// procNetSource is one /proc/net/{tcp,udp}{,6} file to read on every poll.
+type procNetSource struct {
+	file          *os.File
+	proto         string
+	requireListen bool // UDP has no LISTEN state; every bound row counts
+}
+
+// readListenRows seeks each /proc/net file back to the start and re-reads
+// it; the kernel refreshes the contents on every read, no re-open needed.
+func (s *linuxScanner) readListenRows() ([]listenRow, error) {
+	var rows []listenRow
+
+	sources := []procNetSource{
+		{s.tcpFile, ProtoTCP, true},
+		{s.tcp6File, ProtoTCP, true},
+		{s.udpFile, ProtoUDP, false},
+		{s.udp6File, ProtoUDP, false},
+	}
+
+	for _, src := range sources {
+		if src.file == nil {
+			continue
+		}
+		if _, err := src.file.Seek(0, 0); err != nil {
+			return nil, err
+		}
+		parsed, err := parseProcNet(src.file, src.proto, src.requireListen)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, parsed...)
+	}
+
+	return rows, nil
+}
+
+// procNetTCPListenState is the sl:local_address:rem_address:st field value
+// the kernel uses for TCP_LISTEN.
+const procNetTCPListenState = "0A"
+
+func parseProcNet(r *os.File, proto string, requireListen bool) ([]listenRow, error) {
+	var rows []listenRow
+
+	scanner := bufio.NewScanner(r)
+	scanner.Scan() // header
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+
+		if requireListen && fields[3] != procNetTCPListenState {
+			continue
+		}
+
+		address, port, err := decodeHexAddrPort(fields[1])
+		if err != nil {
+			continue
+		}
+
+		inode, err := strconv.ParseUint(fields[9], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		rows = append(rows, listenRow{proto: proto, address: address, port: port, inode: inode})
+	}
+
+	return rows, scanner.Err()
+}
+
+// decodeHexAddrPort decodes a "<hex addr>:<hex port>" field from
+// /proc/net/tcp{,6} into a dotted/colon address and decimal port. Addresses
+// are little-endian per 32-bit word.
+func decodeHexAddrPort(field string) (string, int, error) {
+	parts := strings.SplitN(field, ":", 2)
+	if len(parts) != 2 {
+		return "", 0, fmt.Errorf("malformed local_address field %q", field)
+	}
+
+	addrBytes, err := hex.DecodeString(parts[0])
+	if err != nil {
+		return "", 0, err
+	}
+
+	port, err := strconv.ParseUint(parts[1], 16, 16)
+	if err != nil {
+		return "", 0, err
+	}
+
+	// Each 32-bit word is stored little-endian; reverse word-by-word.
+	for i := 0; i+4 <= len(addrBytes); i += 4 {
+		addrBytes[i], addrBytes[i+1], addrBytes[i+2], addrBytes[i+3] =
+			addrBytes[i+3], addrBytes[i+2], addrBytes[i+1], addrBytes[i]
+	}
+
+	ip := net.IP(addrBytes)
+	address := ip.String()
+	if ip.IsUnspecified() {
+		address = "*"
+	}
+
+	return address, int(port), nil
+}
+
+// refreshPIDCache updates s.inodeToPID for any inode in rows we don't
+// already know about. For each pid still present in /proc, it re-walks
+// /proc/<pid>/fd (cheap: readdir + readlink, no cmdline/cwd/status reads)
+// to get that pid's current socket inodes, but only redoes the expensive
+// part of an entry -- comm/cmdline/cwd/user/stat -- when /proc/<pid>/stat's
+// mtime changed or the fd walk turned up an inode we hadn't seen owned by
+// that pid before (stat's mtime doesn't change just because a process opens
+// another listening socket).
+//
+// Every call lists /proc and evicts any cached pidEntry/inodeToPID entry for
+// a pid that's no longer there, so a long-running watch/serve doesn't
+// accumulate stale entries forever -- independent of whether this poll saw
+// a new inode, since a pid can simply stop listening with nothing else on
+// the box changing.
+func (s *linuxScanner) refreshPIDCache(rows []listenRow) {
+	missing := false
+	for _, row := range rows {
+		if _, ok := s.inodeToPID[row.inode]; !ok {
+			missing = true
+			break
+		}
+	}
+
+	procEntries, err := os.ReadDir("/proc")
+	if err != nil {
+		return
+	}
+
+	livePIDs := make(map[int]bool, len(procEntries))
+	for _, e := range procEntries {
+		if pid, err := strconv.Atoi(e.Name()); err == nil {
+			livePIDs[pid] = true
+		}
+	}
+	s.evictExitedPIDs(livePIDs)
+
+	if !missing {
+		return
+	}
+
+	for pid := range livePIDs {
+		statPath := filepath.Join("/proc", strconv.Itoa(pid), "stat")
+		info, err := os.Stat(statPath)
+		if err != nil {
+			continue // process exited since the ReadDir above
+		}
+
+		inodes := walkFDInodes(pid)
+
+		cached, ok := s.pids[pid]
+		changed := !ok || !cached.statModTime.Equal(info.ModTime())
+		if !changed {
+			for inode := range inodes {
+				if !cached.inodes[inode] {
+					changed = true
+					break
+				}
+			}
+		}
+		if !changed {
+			continue // nothing about this pid changed since last poll
+		}
+
+		ppid, startedAt := readStat(pid, s.bootTime)
+
+		entry := &pidEntry{
+			statModTime: info.ModTime(),
+			inodes:      inodes,
+			process:     readComm(pid),
+			user:        readUser(pid),
+			command:     readCmdline(pid),
+			cwd:         readCwd(pid),
+			ppid:        ppid,
+			startedAt:   startedAt,
+		}
+		s.pids[pid] = entry
+
+		for inode := range entry.inodes {
+			s.inodeToPID[inode] = pid
+		}
+	}
+}
+
+// evictExitedPIDs drops any cached pidEntry/inodeToPID entry owned by a pid
+// not present in livePIDs.
+func (s *linuxScanner) evictExitedPIDs(livePIDs map[int]bool) {
+	for pid := range s.pids {
+		if !livePIDs[pid] {
+			delete(s.pids, pid)
+		}
+	}
+	for inode, pid := range s.inodeToPID {
+		if !livePIDs[pid] {
+			delete(s.inodeToPID, inode)
+		}
+	}
+}
+
+// walkFDInodes returns the socket inodes owned by pid's open file
+// descriptors, read from the `socket:[<inode>]` symlink targets.
+func walkFDInodes(pid int) map[uint64]bool {
+	inodes := make(map[uint64]bool)
+
+	fdDir := filepath.Join("/proc", strconv.Itoa(pid), "fd")
+	entries, err := os.ReadDir(fdDir)
+	if err != nil {
+		return inodes
+	}
+
+	for _, fd := range entries {
+		target, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+		if err != nil {
+			continue
+		}
+		if !strings.HasPrefix(target, "socket:[") {
+			continue
+		}
+		inodeStr := strings.TrimSuffix(strings.TrimPrefix(target, "socket:["), "]")
+		inode, err := strconv.ParseUint(inodeStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		inodes[inode] = true
+	}
+
+	return inodes
+}
+
+func readComm(pid int) string {
+	data, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "comm"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func readUser(pid int) string {
+	data, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "status"))
+	if err != nil {
+		return ""
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(line, "Uid:") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return ""
+		}
+		uid, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return fields[1]
+		}
+		if u, err := lookupUser(uid); err == nil {
+			return u
+		}
+		return fields[1]
+	}
+
+	return ""
+}
+
+func lookupUser(uid int) (string, error) {
+	u, err := user.LookupId(strconv.Itoa(uid))
+	if err != nil {
+		return "", err
+	}
+	return u.Username, nil
+}
+
+// linuxClockTicksPerSecond is the kernel's USER_HZ, used to convert
+// /proc/<pid>/stat's starttime field into wall-clock time. There's no
+// portable way to read sysconf(_SC_CLK_TCK) without cgo, and 100 is the
+// value on every mainstream distro kernel, so it's hard-coded.
+const linuxClockTicksPerSecond = 100
+
+// readCmdline returns pid's command line with NUL argument separators
+// joined by spaces, e.g. "/usr/bin/node server.js".
+func readCmdline(pid int) string {
+	data, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "cmdline"))
+	if err != nil {
+		return ""
+	}
+	data = bytes.TrimRight(data, "\x00")
+	return string(bytes.ReplaceAll(data, []byte{0}, []byte(" ")))
+}
+
+// readCwd returns pid's current working directory via the /proc/<pid>/cwd
+// symlink.
+func readCwd(pid int) string {
+	target, err := os.Readlink(filepath.Join("/proc", strconv.Itoa(pid), "cwd"))
+	if err != nil {
+		return ""
+	}
+	return target
 }
 
-func (s *linuxScanner) Scan() ([]Port, error) {
-	// Try lsof first (same as macOS)
+// readStat reads pid's parent pid and start time out of /proc/<pid>/stat.
+// The comm field can itself contain spaces and parens, so fields are parsed
+// from after its closing paren rather than by naive whitespace-splitting.
+func readStat(pid int, bootTime time.Time) (ppid int, startedAt time.Time) {
+	data, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return 0, time.Time{}
+	}
+
+	end := bytes.LastIndexByte(data, ')')
+	if end == -1 || end+1 >= len(data) {
+		return 0, time.Time{}
+	}
+
+	fields := strings.Fields(string(data[end+1:]))
+	// fields[0] is state (stat field 3); ppid is field 4, starttime is field 22.
+	if len(fields) < 20 {
+		return 0, time.Time{}
+	}
+
+	ppid, _ = strconv.Atoi(fields[1])
+
+	if !bootTime.IsZero() {
+		if startTicks, err := strconv.ParseFloat(fields[19], 64); err == nil {
+			seconds := startTicks / linuxClockTicksPerSecond
+			startedAt = bootTime.Add(time.Duration(seconds * float64(time.Second)))
+		}
+	}
+
+	return ppid, startedAt
+}
+
+// readBootTime reads the kernel boot time from /proc/stat's "btime" line,
+// used together with /proc/<pid>/stat's starttime to compute a process's
+// absolute start time.
+func readBootTime() time.Time {
+	data, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return time.Time{}
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(line, "btime") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return time.Time{}
+		}
+		secs, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return time.Time{}
+		}
+		return time.Unix(secs, 0)
+	}
+
+	return time.Time{}
+}
+
+// --- lsof/ss fallback, used when /proc/net is unavailable --------------
+
+func (s *linuxScanner) appendListeningViaLsof(dst []Port, opts ScanOptions) ([]Port, error) {
 	cmd := exec.Command("lsof", "-iTCP", "-sTCP:LISTEN", "-P", "-n")
 	output, err := cmd.Output()
 	if err != nil {
-		// lsof might not be installed, try ss
-		return s.scanWithSS()
+		return s.appendListeningViaSS(dst, opts)
+	}
+	dst, err = appendLsofOutputLinux(dst, output, ProtoTCP, opts)
+	if err != nil {
+		return dst, err
+	}
+
+	if udpOutput, err := exec.Command("lsof", "-iUDP", "-P", "-n").Output(); err == nil {
+		dst, _ = appendLsofOutputLinux(dst, udpOutput, ProtoUDP, opts)
 	}
 
-	return parseLsofOutputLinux(output)
+	return dst, nil
 }
 
-func (s *linuxScanner) scanWithSS() ([]Port, error) {
-	// ss -tlnp: TCP, listening, numeric, show process
+func (s *linuxScanner) appendListeningViaSS(dst []Port, opts ScanOptions) ([]Port, error) {
 	cmd := exec.Command("ss", "-tlnp")
 	output, err := cmd.Output()
 	if err != nil {
-		return nil, err
+		return dst, err
+	}
+	dst, err = appendSSOutput(dst, output, ProtoTCP, opts)
+	if err != nil {
+		return dst, err
+	}
+
+	if udpOutput, err := exec.Command("ss", "-ulnp").Output(); err == nil {
+		dst, _ = appendSSOutput(dst, udpOutput, ProtoUDP, opts)
 	}
 
-	return parseSSOutput(output)
+	return dst, nil
 }
 
-func parseLsofOutputLinux(output []byte) ([]Port, error) {
-	var ports []Port
+func appendLsofOutputLinux(dst []Port, output []byte, proto string, opts ScanOptions) ([]Port, error) {
 	seen := make(map[string]bool)
 
 	scanner := bufio.NewScanner(bytes.NewReader(output))
-	// Skip header
-	scanner.Scan()
+	scanner.Scan() // header
 
 	addrRegex := regexp.MustCompile(`^(\*|\[?[^\]]+\]?):(\d+)$`)
 
 	for scanner.Scan() {
-		line := scanner.Text()
-		fields := strings.Fields(line)
+		fields := strings.Fields(scanner.Text())
 		if len(fields) < 9 {
 			continue
 		}
@@ -72,14 +563,19 @@ func parseLsofOutputLinux(output []byte) ([]Port, error) {
 		address := matches[1]
 		port, _ := strconv.Atoi(matches[2])
 
-		key := strconv.Itoa(port) + ":" + strconv.Itoa(pid)
+		if !shouldInclude(address, opts) {
+			continue
+		}
+
+		key := proto + ":" + strconv.Itoa(port) + ":" + strconv.Itoa(pid)
 		if seen[key] {
 			continue
 		}
 		seen[key] = true
 
-		ports = append(ports, Port{
+		dst = append(dst, Port{
 			Port:    port,
+			Proto:   proto,
 			PID:     pid,
 			Process: process,
 			User:    user,
@@ -87,16 +583,14 @@ func parseLsofOutputLinux(output []byte) ([]Port, error) {
 		})
 	}
 
-	return ports, nil
+	return dst, nil
 }
 
-func parseSSOutput(output []byte) ([]Port, error) {
-	var ports []Port
+func appendSSOutput(dst []Port, output []byte, proto string, opts ScanOptions) ([]Port, error) {
 	seen := make(map[string]bool)
 
 	scanner := bufio.NewScanner(bytes.NewReader(output))
-	// Skip header
-	scanner.Scan()
+	scanner.Scan() // header
 
 	// ss output: State Recv-Q Send-Q Local Address:Port Peer Address:Port Process
 	// Example: LISTEN 0 128 0.0.0.0:22 0.0.0.0:* users:(("sshd",pid=1234,fd=3))
@@ -104,13 +598,11 @@ func parseSSOutput(output []byte) ([]Port, error) {
 	procRegex := regexp.MustCompile(`"([^"]+)"`)
 
 	for scanner.Scan() {
-		line := scanner.Text()
-		fields := strings.Fields(line)
+		fields := strings.Fields(scanner.Text())
 		if len(fields) < 5 {
 			continue
 		}
 
-		// Parse local address:port
 		localAddr := fields[3]
 		lastColon := strings.LastIndex(localAddr, ":")
 		if lastColon == -1 {
@@ -120,7 +612,10 @@ func parseSSOutput(output []byte) ([]Port, error) {
 		address := localAddr[:lastColon]
 		port, _ := strconv.Atoi(localAddr[lastColon+1:])
 
-		// Parse process info
+		if !shouldInclude(address, opts) {
+			continue
+		}
+
 		var pid int
 		var process string
 		if len(fields) >= 6 {
@@ -133,22 +628,22 @@ func parseSSOutput(output []byte) ([]Port, error) {
 			}
 		}
 
-		key := strconv.Itoa(port) + ":" + strconv.Itoa(pid)
+		key := proto + ":" + strconv.Itoa(port) + ":" + strconv.Itoa(pid)
 		if seen[key] {
 			continue
 		}
 		seen[key] = true
 
-		ports = append(ports, Port{
+		dst = append(dst, Port{
 			Port:    port,
+			Proto:   proto,
 			PID:     pid,
 			Process: process,
-			User:    "", // ss doesn't show user by default
 			Address: address,
 		})
 	}
 
-	return ports, nil
+	return dst, nil
 }
 
 func (s *linuxScanner) Kill(pid int, force bool) error {
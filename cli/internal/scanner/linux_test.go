@@ -0,0 +1,36 @@
+//go:build linux
+
+package scanner
+
+import "testing"
+
+func TestDecodeHexAddrPort(t *testing.T) {
+	tests := []struct {
+		name        string
+		field       string
+		wantAddress string
+		wantPort    int
+	}{
+		{"ipv4 wildcard", "00000000:0050", "*", 80},
+		{"ipv4 loopback", "0100007F:1F90", "127.0.0.1", 8080},
+		{"ipv6 wildcard", "00000000000000000000000000000000:01BB", "*", 443},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, port, err := decodeHexAddrPort(tt.field)
+			if err != nil {
+				t.Fatalf("decodeHexAddrPort(%q) returned error: %v", tt.field, err)
+			}
+			if address != tt.wantAddress || port != tt.wantPort {
+				t.Fatalf("decodeHexAddrPort(%q) = (%q, %d), want (%q, %d)", tt.field, address, port, tt.wantAddress, tt.wantPort)
+			}
+		})
+	}
+}
+
+func TestDecodeHexAddrPortMalformed(t *testing.T) {
+	if _, _, err := decodeHexAddrPort("not-a-valid-field"); err == nil {
+		t.Fatal("expected an error for a malformed field, got nil")
+	}
+}
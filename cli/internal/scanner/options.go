@@ -0,0 +1,36 @@
+package scanner
+
+import (
+	"net"
+	"strings"
+)
+
+// shouldInclude applies ScanOptions to a single parsed address. It is
+// called after parsing a row but before dedup, so a process bound to both
+// a wildcard and a loopback address still surfaces the wildcard entry.
+func shouldInclude(address string, opts ScanOptions) bool {
+	if !opts.IncludeLocalhost && isLoopback(address) {
+		return false
+	}
+	if opts.Bind != "" && !isWildcard(address) && address != opts.Bind {
+		return false
+	}
+	return true
+}
+
+func isLoopback(address string) bool {
+	ip := net.ParseIP(strings.Trim(address, "[]"))
+	if ip == nil {
+		return false
+	}
+	return ip.IsLoopback()
+}
+
+func isWildcard(address string) bool {
+	switch strings.Trim(address, "[]") {
+	case "*", "0.0.0.0", "::":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,38 @@
+package scanner
+
+import "testing"
+
+func TestShouldInclude(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		opts    ScanOptions
+		want    bool
+	}{
+		{"loopback hidden when IncludeLocalhost is false", "127.0.0.1", ScanOptions{}, false},
+		{"loopback shown when requested", "127.0.0.1", ScanOptions{IncludeLocalhost: true}, true},
+		{"wildcard always passes the loopback filter", "0.0.0.0", ScanOptions{}, true},
+		{"non-matching bind excluded", "10.0.0.5", ScanOptions{IncludeLocalhost: true, Bind: "10.0.0.6"}, false},
+		{"matching bind included", "10.0.0.5", ScanOptions{IncludeLocalhost: true, Bind: "10.0.0.5"}, true},
+		{"wildcard always passes a bind filter", "*", ScanOptions{IncludeLocalhost: true, Bind: "10.0.0.5"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldInclude(tt.address, tt.opts); got != tt.want {
+				t.Fatalf("shouldInclude(%q, %+v) = %v, want %v", tt.address, tt.opts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsWildcard(t *testing.T) {
+	for _, addr := range []string{"*", "0.0.0.0", "::", "[::]"} {
+		if !isWildcard(addr) {
+			t.Errorf("isWildcard(%q) = false, want true", addr)
+		}
+	}
+	if isWildcard("127.0.0.1") {
+		t.Error("isWildcard(\"127.0.0.1\") = true, want false")
+	}
+}
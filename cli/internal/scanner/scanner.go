@@ -1,18 +1,62 @@
 package scanner
 
+import "time"
+
+const (
+	ProtoTCP = "tcp"
+	ProtoUDP = "udp"
+	ProtoAll = "all"
+)
+
 // Port represents a listening port and its associated process
 type Port struct {
 	Port    int    `json:"port"`
+	Proto   string `json:"proto"`
 	PID     int    `json:"pid"`
 	Process string `json:"process"`
 	User    string `json:"user"`
 	Address string `json:"address"`
 	Command string `json:"command,omitempty"`
+
+	// The fields below are populated best-effort and are more expensive to
+	// gather than the rest of Port, so callers that don't need them (the
+	// default table view) shouldn't assume they're always set by every
+	// platform scanner.
+	Cwd       string    `json:"cwd,omitempty"`
+	PPID      int       `json:"ppid,omitempty"`
+	StartedAt time.Time `json:"startedAt,omitempty"`
+}
+
+// ScanOptions controls which listening sockets a scan surfaces.
+type ScanOptions struct {
+	// IncludeLocalhost, when false, drops sockets bound to a loopback
+	// address (127.0.0.0/8, ::1).
+	IncludeLocalhost bool
+	// Bind, when non-empty, drops sockets not bound to this exact address.
+	// Wildcard binds (0.0.0.0, ::, *) always pass, since a process bound to
+	// all interfaces is still reachable on Bind.
+	Bind string
+}
+
+// DefaultScanOptions preserves pre-filter behavior: show every listening
+// socket regardless of bind address.
+func DefaultScanOptions() ScanOptions {
+	return ScanOptions{IncludeLocalhost: true}
 }
 
-// Scanner interface for platform-specific implementations
+// Scanner interface for platform-specific implementations. A Scanner is
+// long-lived: New returns an object that owns whatever OS handles or caches
+// its platform needs, so callers that poll repeatedly (the watch daemon, a
+// GUI) should keep one Scanner around rather than constructing a new one
+// per scan.
 type Scanner interface {
-	Scan() ([]Port, error)
+	// Scan returns the ports currently listening that match opts.
+	Scan(opts ScanOptions) ([]Port, error)
+	// AppendListening scans for listening ports matching opts and appends
+	// them to dst, returning the extended slice. It lets a caller that
+	// polls on an interval reuse its backing array instead of allocating on
+	// every poll.
+	AppendListening(dst []Port, opts ScanOptions) ([]Port, error)
 	Kill(pid int, force bool) error
 }
 
@@ -5,9 +5,11 @@ package scanner
 import (
 	"bufio"
 	"bytes"
+	"fmt"
 	"os/exec"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type windowsScanner struct{}
@@ -16,25 +18,38 @@ func newPlatformScanner() Scanner {
 	return &windowsScanner{}
 }
 
-func (s *windowsScanner) Scan() ([]Port, error) {
+func (s *windowsScanner) Scan(opts ScanOptions) ([]Port, error) {
+	return s.AppendListening(nil, opts)
+}
+
+func (s *windowsScanner) AppendListening(dst []Port, opts ScanOptions) ([]Port, error) {
 	// netstat -ano: all connections, numeric, owner PID
-	cmd := exec.Command("netstat", "-ano", "-p", "TCP")
-	output, err := cmd.Output()
+	tcpOutput, err := exec.Command("netstat", "-ano", "-p", "TCP").Output()
 	if err != nil {
-		return nil, err
+		return dst, err
+	}
+	if dst, err = appendNetstatTCPOutput(dst, tcpOutput, opts); err != nil {
+		return dst, err
 	}
 
-	ports, err := parseNetstatOutput(output)
-	if err != nil {
-		return nil, err
+	// UDP sockets have no LISTENING state in netstat's output; every bound
+	// socket it reports counts.
+	if udpOutput, err := exec.Command("netstat", "-ano", "-p", "UDP").Output(); err == nil {
+		dst, _ = appendNetstatUDPOutput(dst, udpOutput, opts)
 	}
 
 	// Get process names for PIDs
-	return s.enrichWithProcessNames(ports)
+	dst, err = s.enrichWithProcessNames(dst)
+	if err != nil {
+		return dst, err
+	}
+
+	enrichWithProcessDetailsWindows(dst)
+
+	return dst, nil
 }
 
-func parseNetstatOutput(output []byte) ([]Port, error) {
-	var ports []Port
+func appendNetstatTCPOutput(dst []Port, output []byte, opts ScanOptions) ([]Port, error) {
 	seen := make(map[string]bool)
 
 	scanner := bufio.NewScanner(bytes.NewReader(output))
@@ -64,20 +79,70 @@ func parseNetstatOutput(output []byte) ([]Port, error) {
 		address := localAddr[:lastColon]
 		port, _ := strconv.Atoi(localAddr[lastColon+1:])
 
-		key := strconv.Itoa(port) + ":" + strconv.Itoa(pid)
+		if !shouldInclude(address, opts) {
+			continue
+		}
+
+		key := ProtoTCP + ":" + strconv.Itoa(port) + ":" + strconv.Itoa(pid)
 		if seen[key] {
 			continue
 		}
 		seen[key] = true
 
-		ports = append(ports, Port{
+		dst = append(dst, Port{
 			Port:    port,
+			Proto:   ProtoTCP,
 			PID:     pid,
 			Address: address,
 		})
 	}
 
-	return ports, nil
+	return dst, nil
+}
+
+func appendNetstatUDPOutput(dst []Port, output []byte, opts ScanOptions) ([]Port, error) {
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+
+	for scanner.Scan() {
+		fields := strings.Fields(strings.TrimSpace(scanner.Text()))
+		if len(fields) < 4 || fields[0] != "UDP" {
+			continue
+		}
+
+		// Proto Local Address Foreign Address PID
+		// UDP 0.0.0.0:68 *:* 1234
+		localAddr := fields[1]
+		pid, _ := strconv.Atoi(fields[len(fields)-1])
+
+		lastColon := strings.LastIndex(localAddr, ":")
+		if lastColon == -1 {
+			continue
+		}
+
+		address := localAddr[:lastColon]
+		port, _ := strconv.Atoi(localAddr[lastColon+1:])
+
+		if !shouldInclude(address, opts) {
+			continue
+		}
+
+		key := ProtoUDP + ":" + strconv.Itoa(port) + ":" + strconv.Itoa(pid)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		dst = append(dst, Port{
+			Port:    port,
+			Proto:   ProtoUDP,
+			PID:     pid,
+			Address: address,
+		})
+	}
+
+	return dst, nil
 }
 
 func (s *windowsScanner) enrichWithProcessNames(ports []Port) ([]Port, error) {
@@ -111,6 +176,107 @@ func (s *windowsScanner) enrichWithProcessNames(ports []Port) ([]Port, error) {
 	return ports, nil
 }
 
+// enrichWithProcessDetailsWindows fills in Command, PPID, and StartedAt for
+// every port's PID with a single batched wmic query, rather than one exec
+// per port.
+func enrichWithProcessDetailsWindows(ports []Port) {
+	pids := make(map[int]bool, len(ports))
+	for _, p := range ports {
+		if p.PID > 0 {
+			pids[p.PID] = true
+		}
+	}
+	if len(pids) == 0 {
+		return
+	}
+
+	conditions := make([]string, 0, len(pids))
+	for pid := range pids {
+		conditions = append(conditions, fmt.Sprintf("ProcessId=%d", pid))
+	}
+
+	cmd := exec.Command("wmic", "process", "where",
+		strings.Join(conditions, " or "),
+		"get", "CommandLine,ParentProcessId,ProcessId,CreationDate", "/format:csv")
+	output, err := cmd.Output()
+	if err != nil {
+		return
+	}
+
+	details := make(map[int]struct {
+		command   string
+		ppid      int
+		startedAt time.Time
+	}, len(pids))
+
+	var columns []string
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, ",")
+
+		if columns == nil {
+			columns = fields
+			continue
+		}
+		if len(fields) != len(columns) {
+			continue
+		}
+
+		var commandLine, creationDate, parentPID, processID string
+		for i, col := range columns {
+			switch col {
+			case "CommandLine":
+				commandLine = fields[i]
+			case "CreationDate":
+				creationDate = fields[i]
+			case "ParentProcessId":
+				parentPID = fields[i]
+			case "ProcessId":
+				processID = fields[i]
+			}
+		}
+
+		pid, err := strconv.Atoi(processID)
+		if err != nil {
+			continue
+		}
+		ppid, _ := strconv.Atoi(parentPID)
+
+		details[pid] = struct {
+			command   string
+			ppid      int
+			startedAt time.Time
+		}{commandLine, ppid, parseWMICDateTime(creationDate)}
+	}
+
+	for i := range ports {
+		if d, ok := details[ports[i].PID]; ok {
+			ports[i].Command = d.command
+			ports[i].PPID = d.ppid
+			ports[i].StartedAt = d.startedAt
+		}
+	}
+}
+
+// parseWMICDateTime parses the leading yyyyMMddHHmmss of a WMI CIM_DATETIME
+// string (e.g. "20260728102345.123456+060"); the sub-second and UTC offset
+// components are dropped rather than hand-rolled, since Go's time layouts
+// can't express a minutes-based zone offset.
+func parseWMICDateTime(s string) time.Time {
+	if len(s) < 14 {
+		return time.Time{}
+	}
+	t, err := time.ParseInLocation("20060102150405", s[:14], time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
 func (s *windowsScanner) Kill(pid int, force bool) error {
 	args := []string{"/PID", strconv.Itoa(pid)}
 	if force {
@@ -0,0 +1,18 @@
+//go:build windows
+
+package server
+
+import (
+	"net"
+
+	"github.com/Microsoft/go-winio"
+)
+
+// pipeName is the named pipe the server listens on on windows. Clients
+// reach it only when PORTKILLER_SOCKET is set to this path.
+const pipeName = `\\.\pipe\portkiller`
+
+// Listen opens the named pipe at pipeName.
+func Listen() (net.Listener, error) {
+	return winio.ListenPipe(pipeName, nil)
+}
@@ -0,0 +1,6 @@
+// Package pb holds the generated protobuf/gRPC bindings for
+// proto/portkiller.proto. Regenerate with `go generate ./...` after editing
+// the .proto (requires protoc and protoc-gen-go/protoc-gen-go-grpc on PATH).
+package pb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative -I ../proto ../proto/portkiller.proto
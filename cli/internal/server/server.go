@@ -0,0 +1,222 @@
+// Package server implements the portkiller control daemon: a gRPC service,
+// backed by a single long-lived scanner.Scanner, that lets the GUI and any
+// number of CLI invocations share one privileged view of listening ports
+// without each spawning their own subprocesses.
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/productdevbook/port-killer/cli/internal/scanner"
+	"github.com/productdevbook/port-killer/cli/internal/server/pb"
+	"google.golang.org/grpc"
+)
+
+const (
+	socketDir  = ".portkiller"
+	socketName = "portkiller.sock"
+
+	// watchPollInterval governs how often an active Watch RPC re-scans to
+	// compute the next diff. It intentionally matches the CLI watcher's
+	// default so "portkiller watch" and "portkiller serve" clients see
+	// events at the same cadence.
+	watchPollInterval = 2 * time.Second
+)
+
+// SocketPath returns the Unix domain socket the server listens on,
+// ~/.portkiller/portkiller.sock. Clients reach it only when PORTKILLER_SOCKET
+// is set to this path; nothing dials it by default. It is unused on
+// windows, where the daemon instead listens on a fixed named pipe.
+func SocketPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, socketDir, socketName), nil
+}
+
+// Server wraps a scanner.Scanner and exposes it over gRPC.
+type Server struct {
+	pb.UnimplementedPortKillerServer
+
+	mu      sync.Mutex
+	scanner scanner.Scanner
+}
+
+// New creates a Server backed by s.
+func New(s scanner.Scanner) *Server {
+	return &Server{scanner: s}
+}
+
+func scanOptionsFromProto(proto string, includeLocalhost bool, bind string) (scanner.ScanOptions, string, error) {
+	if proto == "" {
+		proto = scanner.ProtoTCP
+	}
+	switch proto {
+	case scanner.ProtoTCP, scanner.ProtoUDP, scanner.ProtoAll:
+	default:
+		return scanner.ScanOptions{}, "", fmt.Errorf("invalid proto %q: must be tcp, udp, or all", proto)
+	}
+	return scanner.ScanOptions{IncludeLocalhost: includeLocalhost, Bind: bind}, proto, nil
+}
+
+func toPBPort(p scanner.Port) *pb.Port {
+	pbp := &pb.Port{
+		Port:    int32(p.Port),
+		Proto:   p.Proto,
+		Pid:     int32(p.PID),
+		Process: p.Process,
+		User:    p.User,
+		Address: p.Address,
+		Command: p.Command,
+		Cwd:     p.Cwd,
+		Ppid:    int32(p.PPID),
+	}
+	if !p.StartedAt.IsZero() {
+		pbp.StartedAt = p.StartedAt.Unix()
+	}
+	return pbp
+}
+
+// scan appends to dst rather than allocating, so a caller that polls on an
+// interval (Watch) can pass its previous result back in and reuse the
+// backing array instead of allocating on every poll; List has no repeat
+// caller to benefit from this and just passes nil.
+func (s *Server) scan(dst []scanner.Port, opts scanner.ScanOptions, proto string) ([]scanner.Port, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ports, err := s.scanner.AppendListening(dst, opts)
+	if err != nil {
+		return nil, err
+	}
+	if proto == scanner.ProtoAll {
+		return ports, nil
+	}
+	filtered := ports[:0]
+	for _, p := range ports {
+		if p.Proto == proto {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered, nil
+}
+
+// List implements pb.PortKillerServer.
+func (s *Server) List(ctx context.Context, req *pb.ListRequest) (*pb.ListResponse, error) {
+	opts, proto, err := scanOptionsFromProto(req.GetProto(), req.GetIncludeLocalhost(), req.GetBind())
+	if err != nil {
+		return nil, err
+	}
+	ports, err := s.scan(nil, opts, proto)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListResponse{Ports: make([]*pb.Port, len(ports))}
+	for i, p := range ports {
+		resp.Ports[i] = toPBPort(p)
+	}
+	return resp, nil
+}
+
+// Kill implements pb.PortKillerServer.
+func (s *Server) Kill(ctx context.Context, req *pb.KillRequest) (*pb.KillResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.scanner.Kill(int(req.GetPid()), req.GetForce()); err != nil {
+		return nil, err
+	}
+	return &pb.KillResponse{}, nil
+}
+
+// portEventKey identifies a watched socket across polls. Port number alone
+// isn't unique: TCP and UDP sockets share a port namespace (e.g. TCP:53 and
+// UDP:53), and a process can bind the same port on both a wildcard and a
+// loopback address, so proto and address are part of the identity too.
+func portEventKey(p scanner.Port) string {
+	return p.Proto + ":" + strconv.Itoa(p.Port) + ":" + p.Address
+}
+
+// Watch implements pb.PortKillerServer. It polls the scanner on the same
+// cadence as "portkiller watch" and streams a PortEvent for every port that
+// appeared, disappeared, or changed owning process since the previous scan.
+func (s *Server) Watch(req *pb.WatchRequest, stream pb.PortKiller_WatchServer) error {
+	opts, proto, err := scanOptionsFromProto(req.GetProto(), req.GetIncludeLocalhost(), req.GetBind())
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	prev := map[string]scanner.Port{}
+	var buf []scanner.Port
+	for {
+		ports, err := s.scan(buf[:0], opts, proto)
+		if err != nil {
+			return err
+		}
+		buf = ports
+
+		next := make(map[string]scanner.Port, len(ports))
+		for _, p := range ports {
+			next[portEventKey(p)] = p
+		}
+
+		for key, p := range next {
+			old, existed := prev[key]
+			switch {
+			case !existed:
+				if err := stream.Send(&pb.PortEvent{Kind: pb.PortEventKind_PORT_EVENT_KIND_ADDED, Port: toPBPort(p)}); err != nil {
+					return err
+				}
+			case old.PID != p.PID:
+				if err := stream.Send(&pb.PortEvent{Kind: pb.PortEventKind_PORT_EVENT_KIND_CHANGED, Port: toPBPort(p)}); err != nil {
+					return err
+				}
+			}
+		}
+		for key, p := range prev {
+			if _, stillListening := next[key]; !stillListening {
+				if err := stream.Send(&pb.PortEvent{Kind: pb.PortEventKind_PORT_EVENT_KIND_REMOVED, Port: toPBPort(p)}); err != nil {
+					return err
+				}
+			}
+		}
+		prev = next
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Serve blocks, accepting and handling RPCs on lis until ctx is cancelled.
+func Serve(ctx context.Context, lis net.Listener, s *Server) error {
+	grpcServer := grpc.NewServer()
+	pb.RegisterPortKillerServer(grpcServer, s)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- grpcServer.Serve(lis)
+	}()
+
+	select {
+	case <-ctx.Done():
+		grpcServer.GracefulStop()
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
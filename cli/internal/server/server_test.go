@@ -0,0 +1,21 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/productdevbook/port-killer/cli/internal/scanner"
+)
+
+func TestPortEventKeyDistinguishesProtoAndAddress(t *testing.T) {
+	tcpDNS := scanner.Port{Port: 53, Proto: scanner.ProtoTCP, Address: "*"}
+	udpDNS := scanner.Port{Port: 53, Proto: scanner.ProtoUDP, Address: "*"}
+	if portEventKey(tcpDNS) == portEventKey(udpDNS) {
+		t.Fatalf("tcp:53 and udp:53 collided on key %q; Watch would drop one of them", portEventKey(tcpDNS))
+	}
+
+	wildcard := scanner.Port{Port: 8080, Proto: scanner.ProtoTCP, Address: "*"}
+	loopback := scanner.Port{Port: 8080, Proto: scanner.ProtoTCP, Address: "127.0.0.1"}
+	if portEventKey(wildcard) == portEventKey(loopback) {
+		t.Fatalf("wildcard and loopback dual binds on :8080 collided on key %q", portEventKey(wildcard))
+	}
+}
@@ -0,0 +1,41 @@
+package watcher
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+const (
+	eventsDir  = ".portkiller"
+	eventsFile = "events.jsonl"
+)
+
+// appendEvent appends e as a single JSON line to ~/.portkiller/events.jsonl
+// so the GUI can tail it for a live activity feed.
+func appendEvent(e Event) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(home, eventsDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, eventsFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	_, err = f.Write(data)
+	return err
+}
@@ -0,0 +1,11 @@
+package watcher
+
+// Notifier delivers a user-visible notification for a port state change.
+type Notifier interface {
+	Notify(title, message string) error
+}
+
+// NewNotifier returns a platform-specific Notifier.
+func NewNotifier() Notifier {
+	return newPlatformNotifier()
+}
@@ -0,0 +1,19 @@
+//go:build darwin
+
+package watcher
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+type darwinNotifier struct{}
+
+func newPlatformNotifier() Notifier {
+	return &darwinNotifier{}
+}
+
+func (n *darwinNotifier) Notify(title, message string) error {
+	script := fmt.Sprintf("display notification %q with title %q", message, title)
+	return exec.Command("osascript", "-e", script).Run()
+}
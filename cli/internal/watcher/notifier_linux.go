@@ -0,0 +1,15 @@
+//go:build linux
+
+package watcher
+
+import "os/exec"
+
+type linuxNotifier struct{}
+
+func newPlatformNotifier() Notifier {
+	return &linuxNotifier{}
+}
+
+func (n *linuxNotifier) Notify(title, message string) error {
+	return exec.Command("notify-send", title, message).Run()
+}
@@ -0,0 +1,31 @@
+//go:build windows
+
+package watcher
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+type windowsNotifier struct{}
+
+func newPlatformNotifier() Notifier {
+	return &windowsNotifier{}
+}
+
+func (n *windowsNotifier) Notify(title, message string) error {
+	script := fmt.Sprintf(
+		"New-BurntToastNotification -Text %s, %s",
+		powershellQuote(title), powershellQuote(message),
+	)
+	if err := exec.Command("powershell", "-NoProfile", "-Command", script).Run(); err != nil {
+		// BurntToast may not be installed; fall back to a plain message box.
+		return exec.Command("msg.exe", "*", fmt.Sprintf("%s: %s", title, message)).Run()
+	}
+	return nil
+}
+
+func powershellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
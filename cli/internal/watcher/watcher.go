@@ -0,0 +1,202 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/productdevbook/port-killer/cli/internal/config"
+	"github.com/productdevbook/port-killer/cli/internal/scanner"
+)
+
+const (
+	// defaultInterval is used when the caller passes a non-positive interval.
+	defaultInterval = 2 * time.Second
+	// debounceScans is how many consecutive scans a port must hold its new
+	// state for before a notification fires, to ride out brief flaps.
+	debounceScans = 2
+)
+
+// EventType identifies the kind of port transition an Event describes.
+type EventType string
+
+const (
+	EventStarted EventType = "started"
+	EventStopped EventType = "stopped"
+)
+
+// Event describes a confirmed watched-port transition, suitable for
+// notification and for appending to the GUI-tailed events log.
+type Event struct {
+	Type        EventType `json:"type"`
+	Port        int       `json:"port"`
+	PID         int       `json:"pid,omitempty"`
+	PreviousPID int       `json:"previousPid,omitempty"`
+	Process     string    `json:"process,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// portState is the last observed snapshot for a single watched port.
+type portState struct {
+	listening bool
+	pid       int
+	process   string
+	streak    int
+}
+
+// Watcher polls a scanner.Scanner for the ports listed in config.WatchedPorts
+// and fires a Notifier once a state change has held for debounceScans scans.
+type Watcher struct {
+	scanner  scanner.Scanner
+	store    config.Store
+	notifier Notifier
+	interval time.Duration
+	scanOpts scanner.ScanOptions
+
+	confirmed map[int]portState
+	pending   map[int]portState
+
+	// buf is reused across polls via Scanner.AppendListening so the
+	// interval-driven poll loop doesn't allocate a fresh slice every tick.
+	buf []scanner.Port
+}
+
+// New creates a Watcher that polls s on the given interval and notifies via n.
+// Watched ports are re-read from store on every poll, so config edits made
+// while watch is running take effect without a restart. opts is applied to
+// every poll, so e.g. an operator can watch only externally-reachable ports
+// by setting IncludeLocalhost to false.
+func New(s scanner.Scanner, store config.Store, n Notifier, interval time.Duration, opts scanner.ScanOptions) *Watcher {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	return &Watcher{
+		scanner:   s,
+		store:     store,
+		notifier:  n,
+		interval:  interval,
+		scanOpts:  opts,
+		confirmed: make(map[int]portState),
+		pending:   make(map[int]portState),
+	}
+}
+
+// Run polls until ctx is cancelled, returning ctx.Err().
+func (w *Watcher) Run(ctx context.Context) error {
+	if err := w.poll(); err != nil {
+		fmt.Fprintf(os.Stderr, "portkiller: watch scan failed: %v\n", err)
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := w.poll(); err != nil {
+				fmt.Fprintf(os.Stderr, "portkiller: watch scan failed: %v\n", err)
+			}
+		}
+	}
+}
+
+func (w *Watcher) poll() error {
+	cfg, err := w.store.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if len(cfg.WatchedPorts) == 0 {
+		return nil
+	}
+
+	w.buf, err = w.scanner.AppendListening(w.buf[:0], w.scanOpts)
+	if err != nil {
+		return fmt.Errorf("failed to scan ports: %w", err)
+	}
+
+	listening := make(map[int]scanner.Port, len(w.buf))
+	for _, p := range w.buf {
+		listening[p.Port] = p
+	}
+
+	for _, wp := range cfg.WatchedPorts {
+		p, isListening := listening[wp.Port]
+		w.observe(wp, isListening, p)
+	}
+
+	return nil
+}
+
+// observe feeds one watched port's latest snapshot into the debounce state
+// machine, firing a notification once a transition is confirmed.
+func (w *Watcher) observe(wp config.WatchedPort, isListening bool, p scanner.Port) {
+	prev, seen := w.confirmed[wp.Port]
+	if !seen {
+		// First observation: seed state without firing a spurious event.
+		w.confirmed[wp.Port] = portState{listening: isListening, pid: p.PID, process: p.Process}
+		return
+	}
+
+	if isListening == prev.listening {
+		delete(w.pending, wp.Port)
+		return
+	}
+
+	pend, pending := w.pending[wp.Port]
+	if !pending || pend.listening != isListening {
+		pend = portState{listening: isListening, pid: p.PID, process: p.Process}
+	}
+	pend.streak++
+	w.pending[wp.Port] = pend
+
+	if pend.streak < debounceScans {
+		return
+	}
+	delete(w.pending, wp.Port)
+
+	event := Event{Port: wp.Port, Timestamp: time.Now()}
+	if isListening {
+		event.Type = EventStarted
+		event.PID = p.PID
+		event.Process = p.Process
+	} else {
+		event.Type = EventStopped
+		event.PreviousPID = prev.pid
+		event.Process = prev.process
+	}
+	w.confirmed[wp.Port] = portState{listening: isListening, pid: p.PID, process: p.Process}
+
+	if (event.Type == EventStarted && !wp.NotifyOnStart) || (event.Type == EventStopped && !wp.NotifyOnStop) {
+		return
+	}
+	w.fire(event)
+}
+
+func (w *Watcher) fire(event Event) {
+	if err := w.notifier.Notify("portkiller", notificationMessage(event)); err != nil {
+		fmt.Fprintf(os.Stderr, "portkiller: notify failed: %v\n", err)
+	}
+	if err := appendEvent(event); err != nil {
+		fmt.Fprintf(os.Stderr, "portkiller: failed to record event: %v\n", err)
+	}
+}
+
+func notificationMessage(event Event) string {
+	switch event.Type {
+	case EventStarted:
+		process := event.Process
+		if process == "" {
+			process = "unknown process"
+		}
+		return fmt.Sprintf("Port %d is now listening (%s, PID %d)", event.Port, process, event.PID)
+	default:
+		process := event.Process
+		if process == "" {
+			process = "unknown process"
+		}
+		return fmt.Sprintf("Port %d stopped listening (%s, PID %d)", event.Port, process, event.PreviousPID)
+	}
+}
@@ -0,0 +1,88 @@
+package watcher
+
+import (
+	"testing"
+
+	"github.com/productdevbook/port-killer/cli/internal/config"
+	"github.com/productdevbook/port-killer/cli/internal/scanner"
+)
+
+type fakeNotifier struct {
+	calls int
+}
+
+func (f *fakeNotifier) Notify(title, message string) error {
+	f.calls++
+	return nil
+}
+
+// newTestWatcher builds a Watcher with just enough state for observe() to
+// run, and redirects HOME so appendEvent's write doesn't touch the real
+// user's ~/.portkiller.
+func newTestWatcher(t *testing.T, n Notifier) *Watcher {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	return &Watcher{
+		notifier:  n,
+		confirmed: make(map[int]portState),
+		pending:   make(map[int]portState),
+	}
+}
+
+func TestObserveDebouncesBeforeFiring(t *testing.T) {
+	n := &fakeNotifier{}
+	w := newTestWatcher(t, n)
+	wp := config.WatchedPort{Port: 8080, NotifyOnStart: true, NotifyOnStop: true}
+
+	w.observe(wp, false, scanner.Port{}) // seed: not listening, no event
+
+	w.observe(wp, true, scanner.Port{Port: 8080, PID: 123, Process: "app"})
+	if n.calls != 0 {
+		t.Fatalf("expected no notification before debounceScans confirmations, got %d", n.calls)
+	}
+
+	w.observe(wp, true, scanner.Port{Port: 8080, PID: 123, Process: "app"})
+	if n.calls != 1 {
+		t.Fatalf("expected exactly one notification after %d confirmations, got %d", debounceScans, n.calls)
+	}
+	if !w.confirmed[8080].listening {
+		t.Fatal("expected port 8080 to be confirmed listening")
+	}
+}
+
+func TestObserveResetsStreakOnFlap(t *testing.T) {
+	n := &fakeNotifier{}
+	w := newTestWatcher(t, n)
+	wp := config.WatchedPort{Port: 8080, NotifyOnStart: true, NotifyOnStop: true}
+
+	w.observe(wp, false, scanner.Port{}) // seed
+
+	w.observe(wp, true, scanner.Port{Port: 8080, PID: 123})  // streak 1
+	w.observe(wp, false, scanner.Port{})                     // flap back: pending reset
+	w.observe(wp, true, scanner.Port{Port: 8080, PID: 123})  // streak 1 again
+	if n.calls != 0 {
+		t.Fatalf("a flap shouldn't confirm a transition, got %d notifications", n.calls)
+	}
+
+	w.observe(wp, true, scanner.Port{Port: 8080, PID: 123}) // streak 2: confirms
+	if n.calls != 1 {
+		t.Fatalf("expected exactly one notification once the flap settled, got %d", n.calls)
+	}
+}
+
+func TestObserveSkipsDisabledNotifications(t *testing.T) {
+	n := &fakeNotifier{}
+	w := newTestWatcher(t, n)
+	wp := config.WatchedPort{Port: 9090, NotifyOnStart: false, NotifyOnStop: true}
+
+	w.observe(wp, false, scanner.Port{}) // seed
+	w.observe(wp, true, scanner.Port{Port: 9090, PID: 1})
+	w.observe(wp, true, scanner.Port{Port: 9090, PID: 1})
+
+	if n.calls != 0 {
+		t.Fatalf("NotifyOnStart=false should suppress the notification, got %d calls", n.calls)
+	}
+	if !w.confirmed[9090].listening {
+		t.Fatal("confirmed state should update even when the notification is suppressed")
+	}
+}